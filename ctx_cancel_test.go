@@ -0,0 +1,79 @@
+package ledger_cosmos_go
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// blockingLedgerDevice implements ledger_go.LedgerDevice with an Exchange
+// that blocks until unblock is closed, and records whether Close was called,
+// for exercising the exchange fallback's ctx-cancellation path without real
+// hardware.
+type blockingLedgerDevice struct {
+	unblock chan struct{}
+	closed  chan struct{}
+}
+
+func newBlockingLedgerDevice() *blockingLedgerDevice {
+	return &blockingLedgerDevice{
+		unblock: make(chan struct{}),
+		closed:  make(chan struct{}),
+	}
+}
+
+func (b *blockingLedgerDevice) Exchange(_ []byte) ([]byte, error) {
+	<-b.unblock
+	return nil, errors.New("blockingLedgerDevice: Exchange unblocked after the test observed cancellation")
+}
+
+func (b *blockingLedgerDevice) Close() error {
+	close(b.closed)
+	return nil
+}
+
+func Test_GetVersionCtx_CancellationReturnsPromptly(t *testing.T) {
+	device := newBlockingLedgerDevice()
+	ledger := &LedgerCosmos{api: device}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := ledger.GetVersionCtx(ctx)
+	elapsed := time.Since(start)
+
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, elapsed, time.Second, "exchange should return as soon as ctx is done, not wait for the blocked device")
+
+	select {
+	case <-device.closed:
+	case <-time.After(time.Second):
+		t.Fatal("expected Close to be called on cancellation")
+	}
+}
+
+func Test_SignSECP256K1Ctx_CancellationReturnsPromptly(t *testing.T) {
+	device := newBlockingLedgerDevice()
+	ledger := &LedgerCosmos{api: device, version: VersionInfo{Major: 2}}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := ledger.SignSECP256K1Ctx(ctx, []uint32{44, 118, 0, 0, 0}, []byte("transaction"))
+	elapsed := time.Since(start)
+
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, elapsed, time.Second, "exchange should return as soon as ctx is done, not wait for the blocked device")
+
+	select {
+	case <-device.closed:
+	case <-time.After(time.Second):
+		t.Fatal("expected Close to be called on cancellation")
+	}
+}
@@ -0,0 +1,126 @@
+/*******************************************************************************
+*   (c) 2018 ZondaX GmbH
+*
+*  Licensed under the Apache License, Version 2.0 (the "License");
+*  you may not use this file except in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*      http://www.apache.org/licenses/LICENSE-2.0
+*
+*  Unless required by applicable law or agreed to in writing, software
+*  distributed under the License is distributed on an "AS IS" BASIS,
+*  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*  See the License for the specific language governing permissions and
+*  limitations under the License.
+********************************************************************************/
+
+package ledger_cosmos_go
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	hardenedOffset = 0x80000000
+	bip44Purpose   = 44
+)
+
+// DerivationPath is a validated BIP44 HD derivation path of the shape
+// m/44'/coin_type'/account'/change/address_index.
+type DerivationPath struct {
+	Purpose      uint32
+	CoinType     uint32
+	Account      uint32
+	Change       uint32
+	AddressIndex uint32
+}
+
+// ParseDerivationPath parses a path such as "m/44'/118'/0'/0/0" into a
+// DerivationPath, rejecting paths whose length is not 5, whose purpose is not
+// 44', or whose first three components are not hardened.
+func ParseDerivationPath(path string) (DerivationPath, error) {
+	components := strings.Split(strings.TrimPrefix(path, "m/"), "/")
+	if len(components) != 5 {
+		return DerivationPath{}, fmt.Errorf("derivation path must have 5 components (m/44'/coin'/account'/change/address_index), got %d", len(components))
+	}
+
+	values := make([]uint32, len(components))
+	for i, c := range components {
+		hardened := strings.HasSuffix(c, "'")
+		c = strings.TrimSuffix(c, "'")
+
+		v, err := strconv.ParseUint(c, 10, 32)
+		if err != nil {
+			return DerivationPath{}, fmt.Errorf("invalid path component %q: %v", components[i], err)
+		}
+
+		if i < 3 && !hardened {
+			return DerivationPath{}, fmt.Errorf("path component %q must be hardened", components[i])
+		}
+		if i >= 3 && hardened {
+			return DerivationPath{}, fmt.Errorf("path component %q must not be hardened", components[i])
+		}
+
+		if hardened {
+			v |= hardenedOffset
+		}
+		values[i] = uint32(v)
+	}
+
+	path32 := DerivationPath{
+		Purpose:      values[0],
+		CoinType:     values[1],
+		Account:      values[2],
+		Change:       values[3],
+		AddressIndex: values[4],
+	}
+
+	if err := validateBip32Path(path32.Uint32(), 0); err != nil {
+		return DerivationPath{}, err
+	}
+
+	return path32, nil
+}
+
+// Uint32 returns the path as the []uint32 slice expected by SignSECP256K1 and
+// GetAddressPubKeySECP256K1.
+func (p DerivationPath) Uint32() []uint32 {
+	return []uint32{p.Purpose, p.CoinType, p.Account, p.Change, p.AddressIndex}
+}
+
+// validateBip32Path checks that bip32Path has the BIP44
+// m/44'/coin_type'/account'/change/address_index shape the Cosmos app
+// expects: exactly 5 components, purpose 44', the first three components
+// hardened and the last two not, and, when wantCoinType is non-zero, a coin
+// type matching wantCoinType.
+func validateBip32Path(bip32Path []uint32, wantCoinType uint32) error {
+	if len(bip32Path) != 5 {
+		return fmt.Errorf("derivation path must have 5 components, got %d", len(bip32Path))
+	}
+
+	if bip32Path[0] != hardenedOffset|bip44Purpose {
+		return fmt.Errorf("unexpected purpose %d', expected 44'", bip32Path[0]&^hardenedOffset)
+	}
+
+	for i := 0; i < 3; i++ {
+		if bip32Path[i]&hardenedOffset == 0 {
+			return fmt.Errorf("path component %d must be hardened", i)
+		}
+	}
+	for i := 3; i < 5; i++ {
+		if bip32Path[i]&hardenedOffset != 0 {
+			return fmt.Errorf("path component %d must not be hardened", i)
+		}
+	}
+
+	if wantCoinType != 0 {
+		coinType := bip32Path[1] &^ hardenedOffset
+		if coinType != wantCoinType {
+			return fmt.Errorf("unexpected coin type %d', expected %d'", coinType, wantCoinType)
+		}
+	}
+
+	return nil
+}
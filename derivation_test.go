@@ -0,0 +1,102 @@
+package ledger_cosmos_go
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseDerivationPath(t *testing.T) {
+	path, err := ParseDerivationPath("m/44'/118'/0'/0/0")
+	require.NoError(t, err)
+	assert.Equal(t, DerivationPath{
+		Purpose:      hardenedOffset | 44,
+		CoinType:     hardenedOffset | 118,
+		Account:      hardenedOffset,
+		Change:       0,
+		AddressIndex: 0,
+	}, path)
+	assert.Equal(t, []uint32{hardenedOffset | 44, hardenedOffset | 118, hardenedOffset, 0, 0}, path.Uint32())
+}
+
+func Test_ParseDerivationPath_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+	}{
+		{"too few components", "m/44'/118'/0'/0"},
+		{"too many components", "m/44'/118'/0'/0/0/0"},
+		{"wrong purpose", "m/43'/118'/0'/0/0"},
+		{"unhardened account", "m/44'/118'/0/0/0"},
+		{"hardened change", "m/44'/118'/0'/0'/0"},
+		{"hardened address index", "m/44'/118'/0'/0/0'"},
+		{"not a number", "m/44'/118'/x'/0/0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseDerivationPath(tt.path)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func Test_ConfigureCosmosLedger_DefaultTemplateFollowsCoinType(t *testing.T) {
+	ledger := &LedgerCosmos{}
+	require.NoError(t, configureCosmosLedger(ledger, WithCoinType(330)))
+
+	path, err := ledger.DerivationPathAt(7)
+	require.NoError(t, err)
+	assert.Equal(t, hardenedOffset|uint32(330), path.CoinType)
+	assert.Equal(t, hardenedOffset|uint32(7), path.Account)
+}
+
+func Test_ConfigureCosmosLedger_NoOptionsDefaultsToAtom(t *testing.T) {
+	ledger := &LedgerCosmos{}
+	require.NoError(t, configureCosmosLedger(ledger))
+
+	path, err := ledger.DerivationPathAt(0)
+	require.NoError(t, err)
+	assert.Equal(t, hardenedOffset|uint32(118), path.CoinType)
+}
+
+func Test_ConfigureCosmosLedger_MatchingTemplateAndCoinTypeAccepted(t *testing.T) {
+	ledger := &LedgerCosmos{}
+	err := configureCosmosLedger(ledger, WithCoinType(330), WithHDPathTemplate("m/44'/330'/%d'/0/0"))
+	require.NoError(t, err)
+
+	path, err := ledger.DerivationPathAt(0)
+	require.NoError(t, err)
+	assert.Equal(t, hardenedOffset|uint32(330), path.CoinType)
+}
+
+func Test_ConfigureCosmosLedger_MismatchedTemplateAndCoinTypeRejected(t *testing.T) {
+	ledger := &LedgerCosmos{}
+	err := configureCosmosLedger(ledger, WithCoinType(330), WithHDPathTemplate("m/44'/118'/%d'/0/0"))
+	assert.Error(t, err)
+}
+
+func Test_ConfigureCosmosLedger_TemplateWithoutCoinTypeAccepted(t *testing.T) {
+	ledger := &LedgerCosmos{}
+	err := configureCosmosLedger(ledger, WithHDPathTemplate("m/44'/459'/%d'/0/0"))
+	require.NoError(t, err)
+
+	path, err := ledger.DerivationPathAt(0)
+	require.NoError(t, err)
+	assert.Equal(t, hardenedOffset|uint32(459), path.CoinType)
+}
+
+func Test_ValidateBip32Path(t *testing.T) {
+	hardened := func(v uint32) uint32 { return hardenedOffset | v }
+
+	validPath := []uint32{hardened(44), hardened(118), hardened(0), 0, 0}
+	require.NoError(t, validateBip32Path(validPath, 0))
+	require.NoError(t, validateBip32Path(validPath, 118))
+
+	assert.Error(t, validateBip32Path(validPath, 330), "wrong coin type should be rejected")
+	assert.Error(t, validateBip32Path([]uint32{44, 118, 0, 0, 0}, 0), "unhardened purpose/coin/account should be rejected")
+	assert.Error(t, validateBip32Path([]uint32{hardened(44), hardened(118), hardened(0), 0}, 0), "wrong length should be rejected")
+	assert.Error(t, validateBip32Path([]uint32{hardened(43), hardened(118), hardened(0), 0, 0}, 0), "wrong purpose should be rejected")
+	assert.Error(t, validateBip32Path([]uint32{hardened(44), hardened(118), hardened(0), hardened(0), 0}, 0), "hardened change should be rejected")
+}
@@ -0,0 +1,93 @@
+package ledger_cosmos_go
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// scriptedLedgerDevice implements ledger_go.LedgerDevice, recording every
+// command it receives and replying with the next entry of responses/errs in
+// sequence, for asserting the exact bytes signv2Direct sends on the wire.
+type scriptedLedgerDevice struct {
+	responses [][]byte
+	errs      []error
+	calls     [][]byte
+}
+
+func (s *scriptedLedgerDevice) Exchange(command []byte) ([]byte, error) {
+	i := len(s.calls)
+	s.calls = append(s.calls, append([]byte{}, command...))
+
+	var response []byte
+	if i < len(s.responses) {
+		response = s.responses[i]
+	}
+	var err error
+	if i < len(s.errs) {
+		err = s.errs[i]
+	}
+	return response, err
+}
+
+func (s *scriptedLedgerDevice) Close() error {
+	return nil
+}
+
+func Test_Signv2Direct_ChunkFraming(t *testing.T) {
+	signDoc := bytes.Repeat([]byte{0xAB}, userMessageChunkSize+50)
+	device := &scriptedLedgerDevice{
+		responses: [][]byte{{}, {}, []byte("signature")},
+	}
+	ledger := &LedgerCosmos{api: device, version: VersionInfo{Major: 2}}
+
+	resp, err := ledger.signv2Direct(context.Background(), []uint32{44, 118, 0, 0, 0}, signDoc)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("signature"), resp)
+	require.Len(t, device.calls, 3)
+
+	pathPacket := device.calls[0]
+	assert.Equal(t, []byte{userCLA, userINSSignSECP256K1Direct, 0, 0}, pathPacket[:4], "packet 1 header must carry the path-only CLA/INS/P1/P2")
+	assert.Equal(t, int(pathPacket[4]), len(pathPacket)-5, "packet 1 length byte must match the path payload")
+
+	firstChunk := device.calls[1]
+	assert.Equal(t, []byte{userCLA, userINSSignSECP256K1Direct, 1, 0, userMessageChunkSize}, firstChunk[:5], "packet 2 header must mark a middle chunk (P1=1)")
+	assert.Equal(t, signDoc[:userMessageChunkSize], firstChunk[5:])
+
+	lastChunk := device.calls[2]
+	remaining := len(signDoc) - userMessageChunkSize
+	assert.Equal(t, []byte{userCLA, userINSSignSECP256K1Direct, 2, 0, byte(remaining)}, lastChunk[:5], "packet 3 header must mark the last chunk (P1=2)")
+	assert.Equal(t, signDoc[userMessageChunkSize:], lastChunk[5:])
+}
+
+func Test_Signv2Direct_ProtoErrorMapping(t *testing.T) {
+	badKeyHandle := errors.New("[APDU_CODE_BAD_KEY_HANDLE] The parameters in the data field are incorrect")
+
+	tests := []struct {
+		name          string
+		deviceMessage string
+		wantSubstring string
+	}{
+		{"nomem", "ERROR: PROTO_ERROR_NOMEM", "not enough memory to decode the SignDoc"},
+		{"inval", "PARSER ERROR: PROTO_ERROR_INVAL", "invalid protobuf encoding in the SignDoc"},
+		{"part", "PARSER ERROR: PROTO_ERROR_PART", "the SignDoc protobuf payload is not complete"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			device := &scriptedLedgerDevice{
+				responses: [][]byte{[]byte(tt.deviceMessage)},
+				errs:      []error{badKeyHandle},
+			}
+			ledger := &LedgerCosmos{api: device, version: VersionInfo{Major: 2}}
+
+			_, err := ledger.signv2Direct(context.Background(), []uint32{44, 118, 0, 0, 0}, []byte("signDoc"))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantSubstring)
+		})
+	}
+}
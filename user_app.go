@@ -17,6 +17,8 @@
 package ledger_cosmos_go
 
 import (
+	"context"
+	"crypto/subtle"
 	"errors"
 	"fmt"
 	"math"
@@ -27,32 +29,309 @@ import (
 const (
 	userCLA = 0x55
 
-	userINSGetVersion       = 0
-	userINSSignSECP256K1    = 2
-	userINSGetAddrSecp256k1 = 4
+	userINSGetVersion          = 0
+	userINSSignSECP256K1       = 2
+	userINSGetAddrSecp256k1    = 4
+	userINSSignSECP256K1Direct = 0x06
 
 	userMessageChunkSize = 250
 )
 
+// minVersionSignModeDirect is the minimum Cosmos app version that understands
+// SIGN_MODE_DIRECT (protobuf SignDoc) payloads via userINSSignSECP256K1Direct.
+var minVersionSignModeDirect = VersionInfo{0, 2, 3, 0}
+
 var (
 	errNotSupportedAppVersion = errors.New("app version not supported")
 	errNotFoundLedgerDevice = errors.New("couldn't find ledger device")
 )
 
+// ErrLedgerPubKeyMismatch is returned when the public key reported by the
+// connected device does not match the public key the caller expected to sign
+// with, e.g. because the wrong Ledger is plugged in.
+var ErrLedgerPubKeyMismatch = errors.New("ledger public key does not match expected public key")
+
+// defaultCoinType is the SLIP-44 coin type (ATOM) used to derive the default
+// HD path template when neither WithCoinType nor WithHDPathTemplate is given.
+const defaultCoinType = 118
+
 // LedgerCosmos represents a connection to the Cosmos app in a Ledger Nano S device
 type LedgerCosmos struct {
 	api     ledger_go.LedgerDevice
 	version VersionInfo
+
+	// expectedPubKey, when set via MustMatchPubKey, is compared against the
+	// device's public key before every subsequent sign call.
+	expectedPubKey []byte
+
+	// coinType, when non-zero, is the SLIP-44 coin type every derivation path
+	// passed to this connection must match.
+	coinType uint32
+	// hdPathTemplate is used by DerivationPathAt to derive a path from an
+	// account index.
+	hdPathTemplate string
+	// validatePaths gates validateBip32Path on the sign/address entry points.
+	// It is only turned on by NewCosmosLedger: the pre-existing
+	// FindLedgerCosmosUserApp family is called with plain BIP44 integers
+	// (e.g. []uint32{44, 118, 0, 0, 0}), which GetBip32bytesv1/v2 harden
+	// internally during wire serialization, whereas validateBip32Path expects
+	// the purpose/coin/account components to already carry the hardened high
+	// bit (as ParseDerivationPath produces). Enforcing it unconditionally
+	// would reject every existing caller of the legacy entry points.
+	validatePaths bool
+}
+
+// Option configures a LedgerCosmos constructed via NewCosmosLedger.
+type Option func(*LedgerCosmos)
+
+// WithCoinType sets the expected SLIP-44 coin type that every derivation path
+// passed to this connection must match (e.g. 118 for ATOM, 330 for Terra, 459
+// for Kava). The default, 0, skips the coin type check.
+//
+// If WithHDPathTemplate is not also given, the default HD path template is
+// derived from coinType instead of defaulting to ATOM, so WithCoinType alone
+// is enough to pick the right chain; if WithHDPathTemplate is given as well,
+// its coin type component must match coinType or NewCosmosLedger returns an
+// error.
+func WithCoinType(coinType uint32) Option {
+	return func(ledger *LedgerCosmos) {
+		ledger.coinType = coinType
+	}
+}
+
+// WithHDPathTemplate sets the HD path template (e.g. "m/44'/330'/%d'/0/0")
+// used by DerivationPathAt to derive a path from an account index. Its coin
+// type component must match WithCoinType, when given.
+func WithHDPathTemplate(template string) Option {
+	return func(ledger *LedgerCosmos) {
+		ledger.hdPathTemplate = template
+	}
+}
+
+// NewCosmosLedger finds a Cosmos user app the same way FindLedgerCosmosUserApp
+// does, then applies opts (e.g. WithCoinType, WithHDPathTemplate) to configure
+// derivation path validation and defaults for the returned connection.
+//
+// Unlike FindLedgerCosmosUserApp, connections returned by NewCosmosLedger
+// validate every bip32Path passed to SignSECP256K1, SignSECP256K1Direct and
+// GetAddressPubKeySECP256K1: it must have 5 components with the
+// purpose/coin/account components already hardened, e.g. as produced by
+// ParseDerivationPath or DerivationPath.Uint32.
+func NewCosmosLedger(opts ...Option) (*LedgerCosmos, error) {
+	ledger, err := FindLedgerCosmosUserApp()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := configureCosmosLedger(ledger, opts...); err != nil {
+		return nil, err
+	}
+
+	return ledger, nil
+}
+
+// configureCosmosLedger applies opts to ledger, then derives a default
+// hdPathTemplate from coinType if WithHDPathTemplate was not given, or checks
+// that an explicitly given template's coin type component agrees with
+// coinType. Factored out of NewCosmosLedger so it can be exercised without
+// real hardware.
+func configureCosmosLedger(ledger *LedgerCosmos, opts ...Option) error {
+	ledger.validatePaths = true
+	for _, opt := range opts {
+		opt(ledger)
+	}
+
+	if ledger.hdPathTemplate == "" {
+		coinType := ledger.coinType
+		if coinType == 0 {
+			coinType = defaultCoinType
+		}
+		ledger.hdPathTemplate = fmt.Sprintf("m/44'/%d'/%%d'/0/0", coinType)
+		return nil
+	}
+
+	if ledger.coinType == 0 {
+		return nil
+	}
+
+	templatePath, err := ledger.DerivationPathAt(0)
+	if err != nil {
+		return fmt.Errorf("invalid HD path template %q: %v", ledger.hdPathTemplate, err)
+	}
+
+	if templateCoinType := templatePath.CoinType &^ hardenedOffset; templateCoinType != ledger.coinType {
+		return fmt.Errorf("HD path template %q has coin type %d', which does not match WithCoinType(%d)", ledger.hdPathTemplate, templateCoinType, ledger.coinType)
+	}
+
+	return nil
+}
+
+// DerivationPathAt formats this connection's HD path template for the given
+// account index and parses the result.
+func (ledger *LedgerCosmos) DerivationPathAt(account int) (DerivationPath, error) {
+	return ParseDerivationPath(fmt.Sprintf(ledger.hdPathTemplate, account))
+}
+
+// MustMatchPubKey pins an expected compressed public key to this connection.
+// Once set, every subsequent SignSECP256K1 (and SignSECP256K1Direct) call
+// verifies the device's public key against it first, returning
+// ErrLedgerPubKeyMismatch instead of silently signing under the wrong key.
+func (ledger *LedgerCosmos) MustMatchPubKey(expected []byte) {
+	ledger.expectedPubKey = expected
+}
+
+func (ledger *LedgerCosmos) checkExpectedPubKey(ctx context.Context, bip32Path []uint32) error {
+	if ledger.expectedPubKey == nil {
+		return nil
+	}
+
+	return ledger.checkExpectedPubKeyAgainst(ctx, bip32Path, ledger.expectedPubKey)
 }
 
-// FindLedgerCosmosUserApp finds a Cosmos user app running in a ledger device
+func (ledger *LedgerCosmos) checkExpectedPubKeyAgainst(ctx context.Context, bip32Path []uint32, expectedCompressedPubKey []byte) error {
+	pubkey, err := ledger.GetPublicKeySECP256K1Ctx(ctx, bip32Path)
+	if err != nil {
+		return err
+	}
+
+	if subtle.ConstantTimeCompare(pubkey, expectedCompressedPubKey) != 1 {
+		return ErrLedgerPubKeyMismatch
+	}
+
+	return nil
+}
+
+// ExchangeCtx is implemented by transports that natively support context
+// cancellation. Transports that only implement the plain ledger_go.LedgerDevice
+// interface fall back to racing their blocking Exchange call against
+// ctx.Done() in (*LedgerCosmos).exchange.
+type ExchangeCtx interface {
+	ExchangeCtx(ctx context.Context, command []byte) ([]byte, error)
+}
+
+// exchange performs a single APDU exchange, honoring ctx cancellation. If the
+// underlying transport implements ExchangeCtx it is used directly; otherwise
+// the blocking Exchange call is raced against ctx.Done(), and on cancellation
+// the transport is closed so the device returns to idle instead of waiting
+// indefinitely for a confirmation that will never come.
+//
+// Known limitation: when racing the fallback goroutine, cancellation closes
+// ledger.api while that goroutine's Exchange call may still be in flight on
+// the same transport handle. ledger_go.LedgerDevice does not document
+// Exchange/Close as safe to call concurrently, so this is a best-effort abort
+// (it unblocks the caller; the abandoned goroutine's result is discarded),
+// not a guaranteed-safe cancellation. A LedgerCosmos whose exchange was
+// cancelled this way should be treated as closed and not reused. Transports
+// that need a clean cancellation should implement ExchangeCtx instead of
+// relying on this fallback.
+func (ledger *LedgerCosmos) exchange(ctx context.Context, message []byte) ([]byte, error) {
+	if ce, ok := ledger.api.(ExchangeCtx); ok {
+		return ce.ExchangeCtx(ctx, message)
+	}
+
+	type result struct {
+		response []byte
+		err      error
+	}
+
+	done := make(chan result, 1)
+	go func() {
+		response, err := ledger.api.Exchange(message)
+		done <- result{response, err}
+	}()
+
+	select {
+	case r := <-done:
+		return r.response, r.err
+	case <-ctx.Done():
+		_ = ledger.api.Close()
+		return nil, ctx.Err()
+	}
+}
+
+// LedgerDeviceInfo describes a connected Ledger device where the Cosmos user
+// app is open and its version is supported by this library.
+//
+// Path is populated on a best-effort basis: it depends on the underlying
+// ledger_go.LedgerAdmin transport being able to report it through
+// ListDevices. The production HID transport currently does not (ListDevices
+// always returns an empty list there), so on real hardware Path is left at
+// its zero value and only Index and Version can be relied on; prefer
+// FindLedgerCosmosUserAppByIndex over FindLedgerCosmosUserAppByPath there.
+//
+// USB product ID and serial number are deliberately not exposed here:
+// ledger_go.LedgerAdmin has no accessor for either on any transport (HID,
+// mock or Zemu), so there is no way to populate them today.
+type LedgerDeviceInfo struct {
+	// Index is the device's position as reported by the underlying transport;
+	// pass it to FindLedgerCosmosUserAppByIndex to connect to this device.
+	Index int
+	// Path is the transport-reported device path (e.g. HID path), when the
+	// underlying transport is able to report one.
+	Path string
+	// Version is the Cosmos app version resolved on that device.
+	Version VersionInfo
+}
+
+// FindLedgerCosmosUserApp finds a Cosmos user app running in a ledger device.
+// If more than one device is connected, the first one found is used; use
+// ListLedgerCosmosUserApps and FindLedgerCosmosUserAppByIndex to pick a
+// specific device instead.
 func FindLedgerCosmosUserApp() (*LedgerCosmos, error) {
+	return FindLedgerCosmosUserAppByIndex(0)
+}
+
+// ListLedgerCosmosUserApps enumerates every connected Ledger device and
+// returns a descriptor for each one where the Cosmos user app is open and its
+// version is supported by this library. Devices that are locked, on the
+// wrong app, or running an unsupported Cosmos app version are skipped.
+func ListLedgerCosmosUserApps() ([]LedgerDeviceInfo, error) {
+	admin := ledger_go.NewLedgerAdmin()
+	count := admin.CountDevices()
+	if count == 0 {
+		return nil, errNotFoundLedgerDevice
+	}
+
+	paths, _ := admin.ListDevices()
+
+	var apps []LedgerDeviceInfo
+	for i := 0; i < count; i++ {
+		app, err := FindLedgerCosmosUserAppByIndex(i)
+		if err != nil {
+			continue
+		}
+
+		path := ""
+		if i < len(paths) {
+			path = paths[i]
+		}
+
+		apps = append(apps, LedgerDeviceInfo{
+			Index:   i,
+			Path:    path,
+			Version: app.version,
+		})
+
+		_ = app.Close()
+	}
+
+	if len(apps) == 0 {
+		return nil, errNotFoundLedgerDevice
+	}
+
+	return apps, nil
+}
+
+// FindLedgerCosmosUserAppByIndex connects to the device at the given index (as
+// returned by ListLedgerCosmosUserApps or counted by the underlying
+// transport) and finds a Cosmos user app running on it.
+func FindLedgerCosmosUserAppByIndex(deviceIndex int) (*LedgerCosmos, error) {
 	admin := ledger_go.NewLedgerAdmin()
 	if admin.CountDevices() == 0 {
 		return nil, errNotFoundLedgerDevice
 	}
 
-	ledgerAPI, err := admin.Connect(0)
+	ledgerAPI, err := admin.Connect(deviceIndex)
 
 	if err != nil {
 		return nil, err
@@ -64,7 +343,7 @@ func FindLedgerCosmosUserApp() (*LedgerCosmos, error) {
 		}
 	}()
 
-	app := LedgerCosmos{ledgerAPI, VersionInfo{}}
+	app := LedgerCosmos{api: ledgerAPI}
 	appVersion, err := app.GetVersion()
 
 	if err != nil {
@@ -82,6 +361,31 @@ func FindLedgerCosmosUserApp() (*LedgerCosmos, error) {
 	return &app, err
 }
 
+// FindLedgerCosmosUserAppByPath connects to the device with the given
+// transport-reported path (e.g. HID path, as returned in LedgerDeviceInfo.Path)
+// and finds a Cosmos user app running on it.
+//
+// This depends on the underlying transport's ListDevices reporting real,
+// matchable paths; the production HID transport in ledger-go does not (it
+// always returns an empty list), so on real hardware this will never match
+// and returns an error — use FindLedgerCosmosUserAppByIndex instead there.
+func FindLedgerCosmosUserAppByPath(hidPath string) (*LedgerCosmos, error) {
+	admin := ledger_go.NewLedgerAdmin()
+
+	paths, err := admin.ListDevices()
+	if err != nil {
+		return nil, err
+	}
+
+	for i, path := range paths {
+		if path != "" && path == hidPath {
+			return FindLedgerCosmosUserAppByIndex(i)
+		}
+	}
+
+	return nil, fmt.Errorf("no ledger device found with path %q (the active transport may not support path-based lookup; try FindLedgerCosmosUserAppByIndex)", hidPath)
+}
+
 // Close closes a connection with the Cosmos user app
 func (ledger *LedgerCosmos) Close() error {
 	return ledger.api.Close()
@@ -106,8 +410,14 @@ func (ledger *LedgerCosmos) CheckVersion(ver VersionInfo) error {
 
 // GetVersion returns the current version of the Cosmos user app
 func (ledger *LedgerCosmos) GetVersion() (*VersionInfo, error) {
+	return ledger.GetVersionCtx(context.Background())
+}
+
+// GetVersionCtx behaves like GetVersion, but returns early with ctx.Err() if
+// ctx is cancelled before the device replies.
+func (ledger *LedgerCosmos) GetVersionCtx(ctx context.Context) (*VersionInfo, error) {
 	message := []byte{userCLA, userINSGetVersion, 0, 0, 0}
-	response, err := ledger.api.Exchange(message)
+	response, err := ledger.exchange(ctx, message)
 
 	if err != nil {
 		return nil, err
@@ -130,20 +440,90 @@ func (ledger *LedgerCosmos) GetVersion() (*VersionInfo, error) {
 // SignSECP256K1 signs a transaction using Cosmos user app
 // this command requires user confirmation in the device
 func (ledger *LedgerCosmos) SignSECP256K1(bip32Path []uint32, transaction []byte) ([]byte, error) {
+	return ledger.SignSECP256K1Ctx(context.Background(), bip32Path, transaction)
+}
+
+// SignSECP256K1Ctx behaves like SignSECP256K1, but checks ctx.Done() between
+// chunks and, if ctx is cancelled mid-stream, sends a best-effort abort by
+// closing the underlying transport so the device returns to idle.
+func (ledger *LedgerCosmos) SignSECP256K1Ctx(ctx context.Context, bip32Path []uint32, transaction []byte) ([]byte, error) {
+	if ledger.validatePaths {
+		if err := validateBip32Path(bip32Path, ledger.coinType); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := ledger.checkExpectedPubKey(ctx, bip32Path); err != nil {
+		return nil, err
+	}
+
 	switch ledger.version.Major {
 	case 1:
-		return ledger.signv1(bip32Path, transaction)
+		return ledger.signv1(ctx, bip32Path, transaction)
 	case 2:
-		return ledger.signv2(bip32Path, transaction)
+		return ledger.signv2(ctx, bip32Path, transaction)
 	default:
 		return nil, errNotSupportedAppVersion
 	}
 }
 
+// SignSECP256K1WithExpectedPubKey signs a transaction only after verifying
+// that the connected device's public key for bip32Path matches
+// expectedCompressedPubKey, returning ErrLedgerPubKeyMismatch if it does not.
+// This guards against silently signing a transaction with the wrong Ledger
+// plugged in.
+func (ledger *LedgerCosmos) SignSECP256K1WithExpectedPubKey(bip32Path []uint32, tx []byte, expectedCompressedPubKey []byte) ([]byte, error) {
+	if err := ledger.checkExpectedPubKeyAgainst(context.Background(), bip32Path, expectedCompressedPubKey); err != nil {
+		return nil, err
+	}
+
+	return ledger.SignSECP256K1(bip32Path, tx)
+}
+
+// SignSECP256K1Direct signs a SIGN_MODE_DIRECT SignDoc (protobuf-encoded
+// body_bytes, auth_info_bytes, chain_id and account_number) using the Cosmos
+// user app. This command requires user confirmation in the device and is
+// only supported by app version 2.3.0 and above.
+func (ledger *LedgerCosmos) SignSECP256K1Direct(bip32Path []uint32, signDocBytes []byte) ([]byte, error) {
+	return ledger.SignSECP256K1DirectCtx(context.Background(), bip32Path, signDocBytes)
+}
+
+// SignSECP256K1DirectCtx behaves like SignSECP256K1Direct, but checks
+// ctx.Done() between chunks and, if ctx is cancelled mid-stream, sends a
+// best-effort abort by closing the underlying transport so the device
+// returns to idle.
+func (ledger *LedgerCosmos) SignSECP256K1DirectCtx(ctx context.Context, bip32Path []uint32, signDocBytes []byte) ([]byte, error) {
+	if ledger.version.Major < 2 {
+		return nil, errNotSupportedAppVersion
+	}
+
+	if err := CheckVersion(ledger.version, minVersionSignModeDirect); err != nil {
+		return nil, err
+	}
+
+	if ledger.validatePaths {
+		if err := validateBip32Path(bip32Path, ledger.coinType); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := ledger.checkExpectedPubKey(ctx, bip32Path); err != nil {
+		return nil, err
+	}
+
+	return ledger.signv2Direct(ctx, bip32Path, signDocBytes)
+}
+
 // GetPublicKeySECP256K1 retrieves the public key for the corresponding bip32 derivation path (compressed)
 // this command DOES NOT require user confirmation in the device
 func (ledger *LedgerCosmos) GetPublicKeySECP256K1(bip32Path []uint32) ([]byte, error) {
-	pubkey, _, err := ledger.getAddressPubKeySECP256K1(bip32Path, "cosmos", false)
+	return ledger.GetPublicKeySECP256K1Ctx(context.Background(), bip32Path)
+}
+
+// GetPublicKeySECP256K1Ctx behaves like GetPublicKeySECP256K1, but returns
+// early with ctx.Err() if ctx is cancelled before the device replies.
+func (ledger *LedgerCosmos) GetPublicKeySECP256K1Ctx(ctx context.Context, bip32Path []uint32) ([]byte, error) {
+	pubkey, _, err := ledger.getAddressPubKeySECP256K1(ctx, bip32Path, "cosmos", false)
 	return pubkey, err
 }
 
@@ -155,7 +535,13 @@ func validHRPByte(b byte) bool {
 // GetAddressPubKeySECP256K1 returns the pubkey (compressed) and address (bech(
 // this command requires user confirmation in the device
 func (ledger *LedgerCosmos) GetAddressPubKeySECP256K1(bip32Path []uint32, hrp string) (pubkey []byte, addr string, err error) {
-	return ledger.getAddressPubKeySECP256K1(bip32Path, hrp, true)
+	return ledger.GetAddressPubKeySECP256K1Ctx(context.Background(), bip32Path, hrp)
+}
+
+// GetAddressPubKeySECP256K1Ctx behaves like GetAddressPubKeySECP256K1, but
+// returns early with ctx.Err() if ctx is cancelled before the device replies.
+func (ledger *LedgerCosmos) GetAddressPubKeySECP256K1Ctx(ctx context.Context, bip32Path []uint32, hrp string) (pubkey []byte, addr string, err error) {
+	return ledger.getAddressPubKeySECP256K1(ctx, bip32Path, hrp, true)
 }
 
 func (ledger *LedgerCosmos) GetBip32bytes(bip32Path []uint32, _ int) ([]byte, error) {
@@ -180,7 +566,7 @@ func (ledger *LedgerCosmos) GetBip32bytes(bip32Path []uint32, _ int) ([]byte, er
 	return pathBytes, nil
 }
 
-func (ledger *LedgerCosmos) signv1(bip32Path []uint32, transaction []byte) ([]byte, error) {
+func (ledger *LedgerCosmos) signv1(ctx context.Context, bip32Path []uint32, transaction []byte) ([]byte, error) {
 	var packetIndex byte = 1
 	var packetCount = 1 + byte(math.Ceil(float64(len(transaction))/float64(userMessageChunkSize)))
 
@@ -189,6 +575,13 @@ func (ledger *LedgerCosmos) signv1(bip32Path []uint32, transaction []byte) ([]by
 	var message []byte
 
 	for packetIndex <= packetCount {
+		select {
+		case <-ctx.Done():
+			_ = ledger.api.Close()
+			return nil, ctx.Err()
+		default:
+		}
+
 		chunk := userMessageChunkSize
 		if packetIndex == 1 {
 			pathBytes, err := ledger.GetBip32bytes(bip32Path, 3)
@@ -205,7 +598,7 @@ func (ledger *LedgerCosmos) signv1(bip32Path []uint32, transaction []byte) ([]by
 			message = append(header, transaction[:chunk]...)
 		}
 
-		response, err := ledger.api.Exchange(message)
+		response, err := ledger.exchange(ctx, message)
 		if err != nil {
 			if err.Error() == "[APDU_CODE_BAD_KEY_HANDLE] The parameters in the data field are incorrect" {
 				// In this special case, we can extract additional info
@@ -233,7 +626,7 @@ func (ledger *LedgerCosmos) signv1(bip32Path []uint32, transaction []byte) ([]by
 	return finalResponse, nil
 }
 
-func (ledger *LedgerCosmos) signv2(bip32Path []uint32, transaction []byte) ([]byte, error) {
+func (ledger *LedgerCosmos) signv2(ctx context.Context, bip32Path []uint32, transaction []byte) ([]byte, error) {
 	var packetIndex byte = 1
 	var packetCount = 1 + byte(math.Ceil(float64(len(transaction))/float64(userMessageChunkSize)))
 
@@ -242,6 +635,13 @@ func (ledger *LedgerCosmos) signv2(bip32Path []uint32, transaction []byte) ([]by
 	var message []byte
 
 	for packetIndex <= packetCount {
+		select {
+		case <-ctx.Done():
+			_ = ledger.api.Close()
+			return nil, ctx.Err()
+		default:
+		}
+
 		chunk := userMessageChunkSize
 		if packetIndex == 1 {
 			pathBytes, err := ledger.GetBip32bytes(bip32Path, 3)
@@ -264,7 +664,7 @@ func (ledger *LedgerCosmos) signv2(bip32Path []uint32, transaction []byte) ([]by
 			message = append(header, transaction[:chunk]...)
 		}
 
-		response, err := ledger.api.Exchange(message)
+		response, err := ledger.exchange(ctx, message)
 		if err != nil {
 			if err.Error() == "[APDU_CODE_BAD_KEY_HANDLE] The parameters in the data field are incorrect" {
 				// In this special case, we can extract additional info
@@ -296,9 +696,85 @@ func (ledger *LedgerCosmos) signv2(bip32Path []uint32, transaction []byte) ([]by
 	return finalResponse, nil
 }
 
+func (ledger *LedgerCosmos) signv2Direct(ctx context.Context, bip32Path []uint32, signDoc []byte) ([]byte, error) {
+	var packetIndex byte = 1
+	var packetCount = 1 + byte(math.Ceil(float64(len(signDoc))/float64(userMessageChunkSize)))
+
+	var finalResponse []byte
+
+	var message []byte
+
+	for packetIndex <= packetCount {
+		select {
+		case <-ctx.Done():
+			_ = ledger.api.Close()
+			return nil, ctx.Err()
+		default:
+		}
+
+		chunk := userMessageChunkSize
+		if packetIndex == 1 {
+			pathBytes, err := ledger.GetBip32bytes(bip32Path, 3)
+			if err != nil {
+				return nil, err
+			}
+			header := []byte{userCLA, userINSSignSECP256K1Direct, 0, 0, byte(len(pathBytes))}
+			message = append(header, pathBytes...)
+		} else {
+			if len(signDoc) < userMessageChunkSize {
+				chunk = len(signDoc)
+			}
+
+			payloadDesc := byte(1)
+			if packetIndex == packetCount {
+				payloadDesc = byte(2)
+			}
+
+			header := []byte{userCLA, userINSSignSECP256K1Direct, payloadDesc, 0, byte(chunk)}
+			message = append(header, signDoc[:chunk]...)
+		}
+
+		response, err := ledger.exchange(ctx, message)
+		if err != nil {
+			if err.Error() == "[APDU_CODE_BAD_KEY_HANDLE] The parameters in the data field are incorrect" {
+				// In this special case, we can extract additional info
+				errorMsg := string(response)
+				switch errorMsg {
+				case "ERROR: PROTO_ERROR_NOMEM":
+					return nil, fmt.Errorf("not enough memory to decode the SignDoc")
+				case "PARSER ERROR: PROTO_ERROR_INVAL":
+					return nil, fmt.Errorf("invalid protobuf encoding in the SignDoc")
+				case "PARSER ERROR: PROTO_ERROR_PART":
+					return nil, fmt.Errorf("the SignDoc protobuf payload is not complete")
+				}
+				return nil, fmt.Errorf(errorMsg)
+			}
+			if err.Error() == "[APDU_CODE_DATA_INVALID] Referenced data reversibly blocked (invalidated)" {
+				errorMsg := string(response)
+				return nil, fmt.Errorf(errorMsg)
+			}
+			return nil, err
+		}
+
+		finalResponse = response
+		if packetIndex > 1 {
+			signDoc = signDoc[chunk:]
+		}
+		packetIndex++
+
+	}
+	return finalResponse, nil
+}
+
 // GetAddressPubKeySECP256K1 returns the pubkey (compressed) and address (bech(
 // this command requires user confirmation in the device
-func (ledger *LedgerCosmos) getAddressPubKeySECP256K1(bip32Path []uint32, hrp string, requireConfirmation bool) (pubkey []byte, addr string, err error) {
+func (ledger *LedgerCosmos) getAddressPubKeySECP256K1(ctx context.Context, bip32Path []uint32, hrp string, requireConfirmation bool) (pubkey []byte, addr string, err error) {
+	if ledger.validatePaths {
+		if err := validateBip32Path(bip32Path, ledger.coinType); err != nil {
+			return nil, "", err
+		}
+	}
+
 	if len(hrp) > 83 {
 		return nil, "", fmt.Errorf("hrp len should be <10")
 	}
@@ -327,7 +803,7 @@ func (ledger *LedgerCosmos) getAddressPubKeySECP256K1(bip32Path []uint32, hrp st
 	message = append(message, pathBytes...)
 	message[4] = byte(len(message) - len(header)) // update length
 
-	response, err := ledger.api.Exchange(message)
+	response, err := ledger.exchange(ctx, message)
 
 	if err != nil {
 		return nil, "", err
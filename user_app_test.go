@@ -0,0 +1,63 @@
+package ledger_cosmos_go
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeLedgerDevice implements ledger_go.LedgerDevice and returns a fixed
+// response regardless of the APDU sent, for exercising the pubkey pinning
+// logic layered on top of the transport without real hardware.
+type fakeLedgerDevice struct {
+	response []byte
+	err      error
+}
+
+func (f *fakeLedgerDevice) Exchange(_ []byte) ([]byte, error) {
+	return f.response, f.err
+}
+
+func (f *fakeLedgerDevice) Close() error {
+	return nil
+}
+
+func newFakePubKeyLedger(pubkey []byte) *LedgerCosmos {
+	response := append(append([]byte{}, pubkey...), []byte("cosmos1fakeaddress")...)
+	return &LedgerCosmos{
+		api:     &fakeLedgerDevice{response: response},
+		version: VersionInfo{Major: 2},
+	}
+}
+
+func Test_CheckExpectedPubKeyAgainst_Match(t *testing.T) {
+	pubkey := bytes.Repeat([]byte{0x02}, 33)
+	ledger := newFakePubKeyLedger(pubkey)
+
+	err := ledger.checkExpectedPubKeyAgainst(context.Background(), []uint32{44, 118, 0, 0, 0}, pubkey)
+	require.NoError(t, err)
+}
+
+func Test_CheckExpectedPubKeyAgainst_Mismatch(t *testing.T) {
+	devicePubkey := bytes.Repeat([]byte{0x02}, 33)
+	expectedPubkey := bytes.Repeat([]byte{0x03}, 33)
+	ledger := newFakePubKeyLedger(devicePubkey)
+
+	err := ledger.checkExpectedPubKeyAgainst(context.Background(), []uint32{44, 118, 0, 0, 0}, expectedPubkey)
+	assert.ErrorIs(t, err, ErrLedgerPubKeyMismatch)
+}
+
+func Test_MustMatchPubKey_GatesSubsequentChecks(t *testing.T) {
+	pubkey := bytes.Repeat([]byte{0x02}, 33)
+	ledger := newFakePubKeyLedger(pubkey)
+
+	// No expected pubkey pinned yet: checkExpectedPubKey is a no-op.
+	require.NoError(t, ledger.checkExpectedPubKey(context.Background(), []uint32{44, 118, 0, 0, 0}))
+
+	ledger.MustMatchPubKey(bytes.Repeat([]byte{0x03}, 33))
+	err := ledger.checkExpectedPubKey(context.Background(), []uint32{44, 118, 0, 0, 0})
+	assert.ErrorIs(t, err, ErrLedgerPubKeyMismatch)
+}